@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/cyverse-de/requests/clients/iplantemail"
+	"github.com/cyverse-de/requests/clients/iplantgroups"
+	"github.com/cyverse-de/requests/clients/notificationagent"
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/cyverse-de/requests/db"
+	"github.com/cyverse-de/requests/model"
+	"github.com/labstack/echo/v4"
+)
+
+// API holds the dependencies shared by every handler.
+type API struct {
+	Echo                    *echo.Echo
+	Title                   string
+	Version                 string
+	DB                      *db.Database
+	UserDomain              string
+	AdminEmail              string
+	IPlantEmailClient       *iplantemail.Client
+	IPlantGroupsClient      *iplantgroups.Client
+	NotificationAgentClient *notificationagent.Client
+	Webhooks                *webhooks.Dispatcher
+}
+
+// ErrorResponse is the body returned by every handler when a request fails.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// RootHandler returns general information about the service itself.
+func (a API) RootHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, model.RootResponse{
+		Service: "requests",
+		Title:   a.Title,
+		Version: a.Version,
+	})
+}
+
+// GetRequestTypesHandler lists every registered request type.
+func (a API) GetRequestTypesHandler(c echo.Context) error {
+	types, err := a.DB.ListRequestTypes(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, model.RequestTypeListing{RequestTypes: types})
+}
+
+// RegisterRequestTypeHandler registers a new request type if one with the
+// same name hasn't been registered already.
+func (a API) RegisterRequestTypeHandler(c echo.Context) error {
+	rt, err := a.DB.AddRequestType(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, rt)
+}
+
+// GetRequestTypeHandler returns a single request type by name.
+func (a API) GetRequestTypeHandler(c echo.Context) error {
+	rt, err := a.DB.GetRequestType(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, rt)
+}
+
+// UpdateRequestTypesHandler is a placeholder for future request type
+// metadata updates; it currently just returns the existing request type.
+func (a API) UpdateRequestTypesHandler(c echo.Context) error {
+	return a.GetRequestTypeHandler(c)
+}
+
+// GetRequestStatusCodesHandler lists every registered request status code.
+func (a API) GetRequestStatusCodesHandler(c echo.Context) error {
+	codes, err := a.DB.ListRequestStatusCodes(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, model.RequestStatusCodeListing{RequestStatusCodes: codes})
+}
+
+// GetRequestsHandler lists every submitted request.
+func (a API) GetRequestsHandler(c echo.Context) error {
+	requests, err := a.DB.ListRequests(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, requests)
+}
+
+// GetRequestDetailsHandler returns a single submitted request by id.
+func (a API) GetRequestDetailsHandler(c echo.Context) error {
+	summary, err := a.DB.GetRequest(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BaggageAttributesMiddleware copies W3C Baggage members named in allowed
+// (e.g. "de.user", "de.request-type", "de.tenant") onto the active span as
+// "baggage.<name>" attributes. The baggage itself is already in the request
+// context by the time this runs, courtesy of the composite propagator
+// installed in pkg/tracing; this middleware only controls what ends up
+// visible on spans, since copying every member unfiltered risks unbounded
+// span cardinality. allowed is typically sourced from the
+// `tracing.baggage_attributes` config key.
+func BaggageAttributesMiddleware(allowed []string) echo.MiddlewareFunc {
+	allowSet := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allowSet[strings.TrimSpace(name)] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			span := trace.SpanFromContext(ctx)
+			if span.IsRecording() {
+				for _, member := range baggage.FromContext(ctx).Members() {
+					if allowSet[member.Key()] {
+						span.SetAttributes(attribute.String("baggage."+member.Key(), member.Value()))
+					}
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/cyverse-de/requests/db"
+	"github.com/cyverse-de/requests/model"
+	"github.com/cyverse-de/requests/pkg/metrics"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// bulkWorkerCount bounds how many items of a single bulk request fan out
+// webhook/notification dispatch concurrently, so a large batch can't exhaust
+// downstream connections.
+const bulkWorkerCount = 8
+
+var bulkTracer = otel.Tracer("github.com/cyverse-de/requests/api")
+
+// BulkRequestSubmission is the body accepted by POST /requests/bulk.
+type BulkRequestSubmission struct {
+	User     string                    `json:"user"`
+	Requests []model.RequestSubmission `json:"requests"`
+}
+
+// BulkSubmitResult is the outcome of submitting a single item of a bulk
+// request. Exactly one of Summary or Error is populated, matching Status.
+type BulkSubmitResult struct {
+	Index   int                   `json:"index"`
+	Status  string                `json:"status"`
+	Summary *model.RequestSummary `json:"summary,omitempty"`
+	Error   *ErrorResponse        `json:"error,omitempty"`
+}
+
+// BulkSubmitRequestHandler submits a batch of administrative requests in a
+// single call. Every item is reported in the response with its own index
+// and status, so once the batch is processed the endpoint answers 200 and
+// callers inspect each result to see what actually happened. A body that
+// can't be bound gets a 400, and a failure to begin or commit the shared
+// transaction gets a 500 instead of per-item results.
+//
+// The inserts all share one DB transaction, with each item wrapped in its
+// own savepoint: a shared *sql.Tx would otherwise be marked aborted by
+// Postgres the moment one item's INSERT fails, taking the rest of the batch
+// down with it, but rolling back to a per-item savepoint keeps the
+// transaction usable for the remaining items. *sql.Tx isn't safe for
+// concurrent use, so this phase is sequential; each item still gets its own
+// child span under the parent "bulkSubmitRequest" span so tracing shows
+// per-item latency. Once the transaction commits, webhook dispatch for the
+// successful items fans out concurrently through a bounded worker pool.
+func (a API) BulkSubmitRequestHandler(c echo.Context) error {
+	ctx, span := bulkTracer.Start(c.Request().Context(), "bulkSubmitRequest")
+	defer span.End()
+
+	var body BulkRequestSubmission
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+
+	tx, err := a.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	defer tx.Rollback()
+
+	results := make([]BulkSubmitResult, len(body.Requests))
+
+	for i, submission := range body.Requests {
+		results[i] = a.submitBulkItemTx(ctx, tx, body.User, i, submission)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+
+	a.dispatchBulkNotifications(results)
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// submitBulkItemTx inserts a single item of a bulk request inside a
+// savepoint on the shared transaction tx, under its own child span of the
+// parent bulkSubmitRequest span.
+func (a API) submitBulkItemTx(ctx context.Context, tx *sql.Tx, user string, index int, submission model.RequestSubmission) BulkSubmitResult {
+	itemCtx, span := bulkTracer.Start(ctx, "bulkSubmitRequestItem")
+	defer span.End()
+
+	result := BulkSubmitResult{Index: index}
+
+	if err := a.Echo.Validator.Validate(&submission); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		result.Status = "error"
+		result.Error = &ErrorResponse{Message: err.Error()}
+		return result
+	}
+
+	var summary model.RequestSummary
+	savepoint := fmt.Sprintf("bulk_item_%d", index)
+	err := db.WithSavepoint(itemCtx, tx, savepoint, func() error {
+		var err error
+		summary, err = db.InsertRequest(itemCtx, tx, submission.RequestType, user, submission.Details)
+		return err
+	})
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		metrics.RequestsSubmittedTotal.WithLabelValues(submission.RequestType, "error").Inc()
+		result.Status = "error"
+		result.Error = &ErrorResponse{Message: err.Error()}
+		return result
+	}
+
+	metrics.RequestsSubmittedTotal.WithLabelValues(submission.RequestType, "ok").Inc()
+	result.Status = "ok"
+	result.Summary = &summary
+	return result
+}
+
+// dispatchBulkNotifications fans webhook, notification-agent, and email
+// delivery for the batch's successful items out across a bounded pool of
+// goroutines. Each item's deliveries run one after another within that
+// item's goroutine, so the notification-agent and email sends for the batch
+// stay capped at bulkWorkerCount concurrent items. Webhook deliveries are not
+// under that cap: Dispatch only looks up the matching subscriptions within
+// the pool and hands each delivery to a background goroutine of its own,
+// which the pool does not wait for. Each goroutine
+// recovers its own panics: it runs detached from the request (decoupled the
+// same way Dispatch decouples a single submission's dispatch), so a panic
+// here isn't caught by Echo's Recover middleware and, left unrecovered,
+// would crash the whole service instead of just this one item's delivery.
+func (a API) dispatchBulkNotifications(results []BulkSubmitResult) {
+	sem := make(chan struct{}, bulkWorkerCount)
+	var wg sync.WaitGroup
+
+	for _, result := range results {
+		if result.Status != "ok" || result.Summary == nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(summary model.RequestSummary) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() { _ = recover() }()
+
+			if a.Webhooks != nil {
+				a.Webhooks.Dispatch(context.Background(), webhooks.Event{
+					RequestID:   summary.ID,
+					RequestType: summary.RequestType,
+					ToStatus:    summary.Status,
+					OccurredAt:  time.Now(),
+				})
+			}
+			if a.NotificationAgentClient != nil {
+				_ = a.NotificationAgentClient.Send(context.Background(), notificationForSummary(summary))
+			}
+			if a.IPlantEmailClient != nil {
+				_ = a.IPlantEmailClient.Send(context.Background(), a.emailForSummary(summary))
+			}
+		}(*result.Summary)
+	}
+
+	wg.Wait()
+}
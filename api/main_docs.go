@@ -15,7 +15,10 @@
 // swagger:meta
 package api
 
-import "github.com/cyverse-de/requests/model"
+import (
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/cyverse-de/requests/model"
+)
 
 // swagger:route GET / misc getRoot
 //
@@ -30,7 +33,7 @@ import "github.com/cyverse-de/requests/model"
 // swagger:response rootResponse
 type rootResponseWrapper struct {
 	// in:body
-	Body RootResponse
+	Body model.RootResponse
 }
 
 // Basic error response.
@@ -140,3 +143,103 @@ type requestSubmission struct {
 	// required:true
 	User *string `json:"user"`
 }
+
+// swagger:route POST /requests/bulk requests bulkSubmitRequest
+//
+// Submit a Batch of Requests
+//
+// This endpoint submits a batch of administrative requests in a single call. Once the batch is processed the
+// response is a 200 with one result per submitted item; failures are reported per item rather than failing the whole
+// batch. A body that can't be parsed gets a 400, and a failure to begin or commit the shared transaction gets a 500.
+//
+// Responses:
+//   200: bulkSubmitResultListing
+//   400: errorResponse
+//   500: errorResponse
+
+// Per-item results of a bulk request submission.
+// swagger:response bulkSubmitResultListing
+type bulkSubmitResultListingWrapper struct {
+	// in:body
+	Body []BulkSubmitResult
+}
+
+// Parameters for the bulk request submission endpoint.
+// swagger:parameters bulkSubmitRequest
+type bulkRequestSubmission struct {
+	// The batch of request submissions
+	//
+	// in:body
+	Body BulkRequestSubmission
+}
+
+// swagger:route POST /webhooks webhooks registerWebhook
+//
+// Register a Webhook Subscription
+//
+// This endpoint registers an HTTPS callback that's notified of request lifecycle events matching its filters.
+//
+// responses:
+//   200: webhookSubscription
+//   400: errorResponse
+
+// swagger:route GET /webhooks webhooks listWebhooks
+//
+// List Webhook Subscriptions
+//
+// This endpoint lists every registered webhook subscription.
+//
+// responses:
+//   200: webhookSubscriptionListing
+
+// swagger:route DELETE /webhooks/{id} webhooks deleteWebhook
+//
+// Delete a Webhook Subscription
+//
+// This endpoint removes a webhook subscription. No further events are delivered to it afterward.
+//
+// responses:
+//   204: description:No Content
+//   404: errorResponse
+
+// swagger:route POST /webhooks/{id}/redeliver/{delivery_id} webhooks redeliverWebhook
+//
+// Redeliver a Webhook Event
+//
+// This endpoint replays a previously logged delivery attempt to its originating subscription.
+//
+// responses:
+//   202: description:Accepted
+//   404: errorResponse
+
+// A registered webhook subscription.
+// swagger:response webhookSubscription
+type webhookSubscriptionWrapper struct {
+	// in:body
+	Body webhooks.Subscription
+}
+
+// Webhook subscription listing response.
+// swagger:response webhookSubscriptionListing
+type webhookSubscriptionListingWrapper struct {
+	// in:body
+	Body []webhooks.Subscription
+}
+
+// Parameters shared by the webhook delete and redeliver endpoints.
+// swagger:parameters deleteWebhook redeliverWebhook
+type webhookIDParameters struct {
+	// the id of the webhook subscription
+	//
+	// in:path
+	ID string `json:"id"`
+}
+
+// Parameters specific to the webhook redeliver endpoint.
+// swagger:parameters redeliverWebhook
+type webhookRedeliverParameters struct {
+	// the id of the delivery attempt to redeliver
+	//
+	// in:path
+	DeliveryID string `json:"delivery_id"`
+}
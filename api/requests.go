@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cyverse-de/requests/clients/iplantemail"
+	"github.com/cyverse-de/requests/clients/notificationagent"
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/cyverse-de/requests/db"
+	"github.com/cyverse-de/requests/model"
+	"github.com/cyverse-de/requests/pkg/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// AddRequestHandler submits a new administrative request.
+func (a API) AddRequestHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var submission model.RequestSubmission
+	if err := c.Bind(&submission); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+	if err := c.Validate(&submission); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+
+	user := c.QueryParam("user")
+
+	summary, err := db.InsertRequest(ctx, a.DB, submission.RequestType, user, submission.Details)
+	if err != nil {
+		metrics.RequestsSubmittedTotal.WithLabelValues(submission.RequestType, "error").Inc()
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	metrics.RequestsSubmittedTotal.WithLabelValues(submission.RequestType, "ok").Inc()
+
+	if a.Webhooks != nil {
+		a.Webhooks.Dispatch(ctx, webhooks.Event{
+			RequestID:   summary.ID,
+			RequestType: summary.RequestType,
+			ToStatus:    summary.Status,
+			OccurredAt:  time.Now(),
+		})
+	}
+	a.notifySubmission(summary)
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// UpdateRequestHandler transitions an existing request to a new status.
+func (a API) UpdateRequestHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	var update model.RequestStatusUpdate
+	if err := c.Bind(&update); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+	if err := c.Validate(&update); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+
+	fromStatus, summary, err := a.DB.UpdateRequestStatus(ctx, id, update.Status)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: err.Error()})
+	}
+	metrics.RequestsStatusTransitionsTotal.WithLabelValues(fromStatus, summary.Status).Inc()
+
+	if a.Webhooks != nil {
+		a.Webhooks.Dispatch(ctx, webhooks.Event{
+			RequestID:   summary.ID,
+			RequestType: summary.RequestType,
+			FromStatus:  fromStatus,
+			ToStatus:    summary.Status,
+			OccurredAt:  time.Now(),
+		})
+	}
+	a.notifySubmission(summary)
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// notificationForSummary builds the in-app notification sent to the
+// requesting user for a submission or status transition.
+func notificationForSummary(summary model.RequestSummary) notificationagent.Notification {
+	return notificationagent.Notification{
+		Type:    "request_status",
+		User:    summary.RequestingUser,
+		Subject: fmt.Sprintf("Your %s request is now %s", summary.RequestType, summary.Status),
+		Payload: map[string]interface{}{"request_id": summary.ID, "status": summary.Status},
+	}
+}
+
+// emailForSummary builds the admin-facing email sent for a submission or
+// status transition.
+func (a API) emailForSummary(summary model.RequestSummary) iplantemail.EmailRequest {
+	return iplantemail.EmailRequest{
+		ToAddress: a.AdminEmail,
+		Template:  "admin-request-notification",
+		Subject:   fmt.Sprintf("New %s request from %s", summary.RequestType, summary.RequestingUser),
+		Values: map[string]interface{}{
+			"request_id":   summary.ID,
+			"request_type": summary.RequestType,
+			"user":         summary.RequestingUser,
+			"status":       summary.Status,
+		},
+	}
+}
+
+// notifySubmission fans a request lifecycle event out to the
+// notification-agent and iplant-email clients, the same way Dispatch fans it
+// out to webhook subscribers: each delivery runs on its own goroutine and
+// notifySubmission returns immediately. Delivery failures are swallowed —
+// these are best-effort side channels, not reasons to fail a submission.
+func (a API) notifySubmission(summary model.RequestSummary) {
+	if a.NotificationAgentClient != nil {
+		go func() {
+			defer func() { _ = recover() }()
+			_ = a.NotificationAgentClient.Send(context.Background(), notificationForSummary(summary))
+		}()
+	}
+	if a.IPlantEmailClient != nil {
+		go func() {
+			defer func() { _ = recover() }()
+			_ = a.IPlantEmailClient.Send(context.Background(), a.emailForSummary(summary))
+		}()
+	}
+}
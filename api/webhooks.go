@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/labstack/echo/v4"
+)
+
+// webhookSubscriptionRequest is the body accepted by POST /webhooks.
+type webhookSubscriptionRequest struct {
+	URL          string   `json:"url" validate:"required,url"`
+	Secret       string   `json:"secret" validate:"required"`
+	RequestTypes []string `json:"request_types,omitempty"`
+	StatusCodes  []string `json:"status_codes,omitempty"`
+}
+
+// webhooksUnavailable is returned by every handler in this file when a.Webhooks
+// is nil, the same way the rest of the API degrades gracefully instead of
+// panicking when an optional dependency isn't configured.
+func webhooksUnavailable(c echo.Context) error {
+	return c.JSON(http.StatusServiceUnavailable, ErrorResponse{Message: "webhook dispatch is not configured"})
+}
+
+// RegisterWebhookHandler registers a new webhook subscription. The callback
+// URL must use HTTPS, since it carries a signed payload administrators rely
+// on to authenticate the request.
+func (a API) RegisterWebhookHandler(c echo.Context) error {
+	if a.Webhooks == nil {
+		return webhooksUnavailable(c)
+	}
+
+	var body webhookSubscriptionRequest
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+	if err := c.Validate(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+	}
+
+	parsed, err := url.Parse(body.URL)
+	if err != nil || parsed.Scheme != "https" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Message: "url must be an https:// callback URL"})
+	}
+
+	sub, err := a.Webhooks.Store.AddSubscription(c.Request().Context(), webhooks.Subscription{
+		URL:          body.URL,
+		Secret:       body.Secret,
+		RequestTypes: body.RequestTypes,
+		StatusCodes:  body.StatusCodes,
+	})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, sub)
+}
+
+// ListWebhooksHandler lists every registered webhook subscription.
+func (a API) ListWebhooksHandler(c echo.Context) error {
+	if a.Webhooks == nil {
+		return webhooksUnavailable(c)
+	}
+
+	subs, err := a.Webhooks.Store.ListSubscriptions(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+	}
+	return c.JSON(http.StatusOK, subs)
+}
+
+// DeleteWebhookHandler removes a webhook subscription.
+func (a API) DeleteWebhookHandler(c echo.Context) error {
+	if a.Webhooks == nil {
+		return webhooksUnavailable(c)
+	}
+
+	id := c.Param("id")
+
+	if err := a.Webhooks.Store.DeleteSubscription(c.Request().Context(), id); err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RedeliverWebhookHandler replays a previously logged delivery attempt to
+// its originating subscription.
+func (a API) RedeliverWebhookHandler(c echo.Context) error {
+	if a.Webhooks == nil {
+		return webhooksUnavailable(c)
+	}
+
+	id := c.Param("id")
+	deliveryID := c.Param("delivery_id")
+
+	if err := a.Webhooks.Redeliver(c.Request().Context(), id, deliveryID); err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Message: err.Error()})
+	}
+
+	return c.NoContent(http.StatusAccepted)
+}
@@ -0,0 +1,37 @@
+// Package httpclient holds small helpers shared by the outbound HTTP clients
+// under clients/, so each one doesn't have to reimplement tracing plumbing.
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// RequestIDBaggageKey is the baggage member used to correlate an
+// administrative request submission across the DE stack, even when the
+// inbound caller didn't set up any baggage of its own.
+const RequestIDBaggageKey = "de.request-id"
+
+// InjectBaggage copies the baggage carried on ctx onto an outbound request's
+// "baggage" header, adding a synthetic de.request-id member when one isn't
+// already present.
+func InjectBaggage(ctx context.Context, req *http.Request) *http.Request {
+	bag := baggage.FromContext(ctx)
+
+	if bag.Member(RequestIDBaggageKey).Key() == "" {
+		if member, err := baggage.NewMember(RequestIDBaggageKey, uuid.NewString()); err == nil {
+			if withMember, err := bag.SetMember(member); err == nil {
+				bag = withMember
+			}
+		}
+	}
+
+	if bag.Len() > 0 {
+		req.Header.Set("baggage", bag.String())
+	}
+
+	return req
+}
@@ -0,0 +1,66 @@
+// Package iplantemail is a thin client for the iplant-email service, used to
+// notify administrators and requesters by email.
+package iplantemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cyverse-de/requests/pkg/metrics"
+)
+
+// Client sends email requests to iplant-email.
+type Client struct {
+	base       string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client that talks to the iplant-email instance at base.
+func NewClient(base string) *Client {
+	return &Client{base: base, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// EmailRequest is the body sent to iplant-email's template endpoint.
+type EmailRequest struct {
+	ToAddress string                 `json:"to-address"`
+	Template  string                 `json:"template"`
+	Subject   string                 `json:"subject"`
+	Values    map[string]interface{} `json:"values"`
+}
+
+// Send posts an email request to iplant-email.
+func (c *Client) Send(ctx context.Context, email EmailRequest) error {
+	body, err := json.Marshal(email)
+	if err != nil {
+		return fmt.Errorf("marshaling email request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building email request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req = withBaggage(ctx, req)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	outcome := "ok"
+	if err != nil || resp.StatusCode >= 300 {
+		outcome = "error"
+	}
+	metrics.ObserveOutboundCall("iplantemail", outcome, time.Since(start))
+
+	if err != nil {
+		return fmt.Errorf("sending email request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("iplant-email returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package iplantgroups is a thin client for the iplant-groups service, used
+// to look up user and group information.
+package iplantgroups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cyverse-de/requests/pkg/metrics"
+)
+
+// Client queries iplant-groups on behalf of user.
+type Client struct {
+	base       string
+	user       string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client that talks to the iplant-groups instance at
+// base, authenticating outbound calls as user.
+func NewClient(base, user string) *Client {
+	return &Client{base: base, user: user, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// UserInfo is the subset of a subject's profile this service cares about.
+type UserInfo struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+}
+
+// GetUser looks up a single subject by username.
+func (c *Client) GetUser(ctx context.Context, username string) (UserInfo, error) {
+	requestURL := fmt.Sprintf("%s/subjects/%s?user=%s", c.base, url.PathEscape(username), url.QueryEscape(c.user))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("building iplant-groups request: %w", err)
+	}
+	req = withBaggage(ctx, req)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	outcome := "ok"
+	if err != nil || resp.StatusCode >= 300 {
+		outcome = "error"
+	}
+	metrics.ObserveOutboundCall("iplantgroups", outcome, time.Since(start))
+
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("querying iplant-groups: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return UserInfo{}, fmt.Errorf("iplant-groups returned %s", resp.Status)
+	}
+
+	var info UserInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return UserInfo{}, fmt.Errorf("decoding iplant-groups response: %w", err)
+	}
+	return info, nil
+}
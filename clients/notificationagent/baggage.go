@@ -0,0 +1,15 @@
+package notificationagent
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cyverse-de/requests/clients/httpclient"
+)
+
+// withBaggage attaches the caller's tracing baggage, plus a correlation id,
+// to a request before it's sent to notification-agent so administrative
+// request submissions can be traced across the DE stack.
+func withBaggage(ctx context.Context, req *http.Request) *http.Request {
+	return httpclient.InjectBaggage(ctx, req)
+}
@@ -0,0 +1,67 @@
+// Package notificationagent is a thin client for the notification-agent
+// service, used to push in-app notifications about request status changes.
+package notificationagent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cyverse-de/requests/pkg/metrics"
+)
+
+// Client posts notifications to notification-agent.
+type Client struct {
+	base       string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client that talks to the notification-agent instance
+// at base.
+func NewClient(base string) *Client {
+	return &Client{base: base, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notification is the body sent to notification-agent.
+type Notification struct {
+	Type    string                 `json:"type"`
+	User    string                 `json:"user"`
+	Subject string                 `json:"subject"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Send posts a notification to notification-agent.
+func (c *Client) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshaling notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/notification", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req = withBaggage(ctx, req)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	outcome := "ok"
+	if err != nil || resp.StatusCode >= 300 {
+		outcome = "error"
+	}
+	metrics.ObserveOutboundCall("notificationagent", outcome, time.Since(start))
+
+	if err != nil {
+		return fmt.Errorf("sending notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification-agent returned %s", resp.Status)
+	}
+	return nil
+}
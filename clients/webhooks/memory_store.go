@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore is a Store implementation that keeps subscriptions and
+// delivery attempts in memory. It's useful for tests and for running the
+// service without a database, but it doesn't survive a restart, so
+// production deployments should use the persisted SQLStore instead.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	subscriptions map[string]Subscription
+	deliveries    map[string]map[string]DeliveryAttempt // subscriptionID -> deliveryID -> attempt
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		subscriptions: make(map[string]Subscription),
+		deliveries:    make(map[string]map[string]DeliveryAttempt),
+	}
+}
+
+func (s *MemoryStore) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+func (s *MemoryStore) GetSubscription(ctx context.Context, id string) (Subscription, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return Subscription{}, fmt.Errorf("no webhook subscription with id %q", id)
+	}
+	return sub, nil
+}
+
+func (s *MemoryStore) AddSubscription(ctx context.Context, sub Subscription) (Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub.ID = uuid.NewString()
+	s.subscriptions[sub.ID] = sub
+	return sub, nil
+}
+
+func (s *MemoryStore) DeleteSubscription(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[id]; !ok {
+		return fmt.Errorf("no webhook subscription with id %q", id)
+	}
+	delete(s.subscriptions, id)
+	delete(s.deliveries, id)
+	return nil
+}
+
+func (s *MemoryStore) AddDeliveryAttempt(ctx context.Context, attempt DeliveryAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt.ID = uuid.NewString()
+	if s.deliveries[attempt.SubscriptionID] == nil {
+		s.deliveries[attempt.SubscriptionID] = make(map[string]DeliveryAttempt)
+	}
+	s.deliveries[attempt.SubscriptionID][attempt.ID] = attempt
+	return nil
+}
+
+func (s *MemoryStore) GetDeliveryAttempt(ctx context.Context, subscriptionID, deliveryID string) (DeliveryAttempt, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	attempts, ok := s.deliveries[subscriptionID]
+	if !ok {
+		return DeliveryAttempt{}, fmt.Errorf("no deliveries logged for subscription %q", subscriptionID)
+	}
+	attempt, ok := attempts[deliveryID]
+	if !ok {
+		return DeliveryAttempt{}, fmt.Errorf("no delivery %q for subscription %q", deliveryID, subscriptionID)
+	}
+	return attempt, nil
+}
@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+)
+
+// Redeliver looks up a previously logged delivery attempt and resends its
+// event to the originating subscription, for POST
+// /webhooks/{id}/redeliver/{delivery_id}.
+func (d *Dispatcher) Redeliver(ctx context.Context, subscriptionID, deliveryID string) error {
+	sub, err := d.Store.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("looking up subscription %s: %w", subscriptionID, err)
+	}
+
+	delivery, err := d.Store.GetDeliveryAttempt(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return fmt.Errorf("looking up delivery %s: %w", deliveryID, err)
+	}
+
+	// Match Dispatch: decouple from ctx (which is cancelled once the HTTP
+	// response is written) and run in the background, since deliverWithRetry
+	// can sleep through the whole backoff schedule on repeated failures —
+	// far longer than the 202 Accepted response this is called from implies.
+	go d.deliverWithRetry(context.Background(), sub, delivery.Event)
+	return nil
+}
@@ -0,0 +1,138 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SQLStore is the persisted Store backing production deployments: it keeps
+// subscriptions and their delivery-attempt log in Postgres so both survive a
+// restart, unlike MemoryStore.
+type SQLStore struct {
+	DB *sql.DB
+}
+
+// NewSQLStore returns a Store backed by db.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db}
+}
+
+func (s *SQLStore) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT id, url, secret, request_types, status_codes, created_at FROM webhook_subscriptions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+func (s *SQLStore) GetSubscription(ctx context.Context, id string) (Subscription, error) {
+	row := s.DB.QueryRowContext(ctx,
+		`SELECT id, url, secret, request_types, status_codes, created_at FROM webhook_subscriptions WHERE id = $1`, id)
+	return scanSubscription(row)
+}
+
+func (s *SQLStore) AddSubscription(ctx context.Context, sub Subscription) (Subscription, error) {
+	sub.ID = uuid.NewString()
+
+	requestTypes, err := json.Marshal(sub.RequestTypes)
+	if err != nil {
+		return Subscription{}, err
+	}
+	statusCodes, err := json.Marshal(sub.StatusCodes)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	row := s.DB.QueryRowContext(ctx,
+		`INSERT INTO webhook_subscriptions (id, url, secret, request_types, status_codes, created_at)
+		 VALUES ($1, $2, $3, $4, $5, now())
+		 RETURNING created_at`,
+		sub.ID, sub.URL, sub.Secret, requestTypes, statusCodes)
+	if err := row.Scan(&sub.CreatedAt); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+func (s *SQLStore) DeleteSubscription(ctx context.Context, id string) error {
+	result, err := s.DB.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("no webhook subscription with id %q", id)
+	}
+	return nil
+}
+
+func (s *SQLStore) AddDeliveryAttempt(ctx context.Context, attempt DeliveryAttempt) error {
+	attempt.ID = uuid.NewString()
+
+	event, err := json.Marshal(attempt.Event)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (id, subscription_id, event, attempt, status_code, error, delivered_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, now())`,
+		attempt.ID, attempt.SubscriptionID, event, attempt.Attempt, attempt.StatusCode, attempt.Error)
+	return err
+}
+
+func (s *SQLStore) GetDeliveryAttempt(ctx context.Context, subscriptionID, deliveryID string) (DeliveryAttempt, error) {
+	var attempt DeliveryAttempt
+	var event []byte
+
+	row := s.DB.QueryRowContext(ctx,
+		`SELECT id, subscription_id, event, attempt, status_code, error, delivered_at
+		 FROM webhook_deliveries WHERE subscription_id = $1 AND id = $2`,
+		subscriptionID, deliveryID)
+	if err := row.Scan(&attempt.ID, &attempt.SubscriptionID, &event, &attempt.Attempt, &attempt.StatusCode, &attempt.Error, &attempt.DeliveredAt); err != nil {
+		return DeliveryAttempt{}, fmt.Errorf("no delivery %q for subscription %q: %w", deliveryID, subscriptionID, err)
+	}
+	if err := json.Unmarshal(event, &attempt.Event); err != nil {
+		return DeliveryAttempt{}, err
+	}
+	return attempt, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner) (Subscription, error) {
+	var sub Subscription
+	var requestTypes, statusCodes []byte
+
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, &requestTypes, &statusCodes, &sub.CreatedAt); err != nil {
+		return Subscription{}, err
+	}
+	if err := json.Unmarshal(requestTypes, &sub.RequestTypes); err != nil {
+		return Subscription{}, err
+	}
+	if err := json.Unmarshal(statusCodes, &sub.StatusCodes); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
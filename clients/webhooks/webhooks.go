@@ -0,0 +1,227 @@
+// Package webhooks lets administrators register HTTPS callbacks that get a
+// signed payload whenever a request's type/status matches a subscription, so
+// external systems can react to request lifecycle events without polling the
+// API. It complements the existing email and notification-agent fan-out with
+// a machine-consumable integration point.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of the
+// delivered payload, hex-encoded.
+const SignatureHeader = "X-DE-Signature"
+
+// Subscription is an admin-registered webhook callback. It fires for any
+// request whose type is in RequestTypes (or any type, if RequestTypes is
+// empty) transitioning to a status code in StatusCodes (or any status, if
+// StatusCodes is empty).
+type Subscription struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	Secret       string    `json:"-"`
+	RequestTypes []string  `json:"request_types,omitempty"`
+	StatusCodes  []string  `json:"status_codes,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Matches reports whether the subscription should fire for the given event.
+func (s Subscription) Matches(e Event) bool {
+	if len(s.RequestTypes) > 0 && !contains(s.RequestTypes, e.RequestType) {
+		return false
+	}
+	if len(s.StatusCodes) > 0 && !contains(s.StatusCodes, e.ToStatus) {
+		return false
+	}
+	return true
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// Event describes a request lifecycle transition, fired from
+// AddRequestHandler (FromStatus empty) and UpdateRequestHandler.
+type Event struct {
+	RequestID   string    `json:"request_id"`
+	RequestType string    `json:"request_type"`
+	FromStatus  string    `json:"from_status,omitempty"`
+	ToStatus    string    `json:"to_status"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// DeliveryAttempt records one attempt to deliver an event to a subscription,
+// whether it succeeded or not, so deliveries can be audited and redelivered.
+type DeliveryAttempt struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Event          Event     `json:"event"`
+	Attempt        int       `json:"attempt"`
+	StatusCode     int       `json:"status_code,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	DeliveredAt    time.Time `json:"delivered_at"`
+}
+
+// Store persists subscriptions and their delivery attempt log. SQLStore is
+// the Postgres-backed implementation used in production; MemoryStore keeps
+// everything in memory.
+type Store interface {
+	ListSubscriptions(ctx context.Context) ([]Subscription, error)
+	GetSubscription(ctx context.Context, id string) (Subscription, error)
+	AddSubscription(ctx context.Context, sub Subscription) (Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+
+	AddDeliveryAttempt(ctx context.Context, attempt DeliveryAttempt) error
+	GetDeliveryAttempt(ctx context.Context, subscriptionID, deliveryID string) (DeliveryAttempt, error)
+}
+
+// backoffSchedule is how long to wait before each retry of a failed
+// delivery; the last entry is reused for any attempt beyond its index.
+var backoffSchedule = []time.Duration{
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+}
+
+// Dispatcher fans a request lifecycle event out to every matching
+// subscription in the background, retrying failed deliveries with
+// exponential backoff and recording every attempt in Store.
+type Dispatcher struct {
+	Store  Store
+	Client *http.Client
+	Log    *logrus.Entry
+
+	maxAttempts int
+}
+
+// NewDispatcher builds a Dispatcher backed by store. maxAttempts bounds how
+// many times a single delivery is attempted, the first try included, before
+// it's left for manual redelivery via the
+// /webhooks/{id}/redeliver/{delivery_id} endpoint. A maxAttempts of zero or
+// less means len(backoffSchedule) attempts.
+func NewDispatcher(store Store, log *logrus.Entry, maxAttempts int) *Dispatcher {
+	if maxAttempts <= 0 {
+		maxAttempts = len(backoffSchedule)
+	}
+	return &Dispatcher{
+		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		Log:         log,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Dispatch looks up subscriptions matching e and delivers to each one on its
+// own goroutine. It returns immediately; callers in AddRequestHandler and
+// UpdateRequestHandler fire-and-forget this the same way they already do for
+// email and notification-agent delivery.
+func (d *Dispatcher) Dispatch(ctx context.Context, e Event) {
+	subs, err := d.Store.ListSubscriptions(ctx)
+	if err != nil {
+		d.Log.WithError(err).Error("listing webhook subscriptions")
+		return
+	}
+
+	for _, sub := range subs {
+		if !sub.Matches(e) {
+			continue
+		}
+		go d.deliverWithRetry(context.Background(), sub, e)
+	}
+}
+
+func (d *Dispatcher) deliverWithRetry(ctx context.Context, sub Subscription, e Event) {
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		statusCode, err := d.deliver(ctx, sub, e, attempt)
+
+		logged := d.Store.AddDeliveryAttempt(ctx, DeliveryAttempt{
+			SubscriptionID: sub.ID,
+			Event:          e,
+			Attempt:        attempt,
+			StatusCode:     statusCode,
+			Error:          errString(err),
+			DeliveredAt:    time.Now(),
+		})
+		if logged != nil {
+			d.Log.WithError(logged).Error("recording webhook delivery attempt")
+		}
+
+		if err == nil {
+			return
+		}
+
+		d.Log.WithError(err).Warnf("webhook delivery to %s failed (attempt %d/%d)", sub.URL, attempt, d.maxAttempts)
+
+		if attempt == d.maxAttempts {
+			return
+		}
+		time.Sleep(backoffDelay(attempt))
+	}
+}
+
+func backoffDelay(attempt int) time.Duration {
+	if attempt-1 < len(backoffSchedule) {
+		return backoffSchedule[attempt-1]
+	}
+	return backoffSchedule[len(backoffSchedule)-1]
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// deliver sends a single signed delivery attempt to sub and returns the
+// response status code (0 if the request never got a response).
+func (d *Dispatcher) deliver(ctx context.Context, sub Subscription, e Event, attempt int) (int, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return 0, fmt.Errorf("marshaling webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+	req.Header.Set("X-DE-Delivery-Attempt", fmt.Sprintf("%d", attempt))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign computes the hex-encoded HMAC-SHA256 of body using secret, for the
+// X-DE-Signature header.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,205 @@
+// Package db is the Postgres-backed storage layer for request types, request
+// status codes, and submitted requests.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cyverse-de/requests/model"
+)
+
+// Database wraps the connection pool used by the rest of the service.
+type Database struct {
+	*sql.DB
+}
+
+// InitDatabase opens and pings a connection pool for driverName/dataSourceName.
+func InitDatabase(driverName, dataSourceName string) (*Database, error) {
+	conn, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Ping(); err != nil {
+		return nil, err
+	}
+	return &Database{DB: conn}, nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx, so callers that need a
+// shared transaction (like the bulk submission endpoint) can pass a *sql.Tx
+// through the same code path a single submission uses with the *Database
+// itself.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ListRequestTypes returns every registered request type, sorted by name.
+func (d *Database) ListRequestTypes(ctx context.Context) ([]model.RequestType, error) {
+	rows, err := d.QueryContext(ctx, `SELECT name, created_at FROM request_types ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []model.RequestType
+	for rows.Next() {
+		var rt model.RequestType
+		if err := rows.Scan(&rt.Name, &rt.CreatedAt); err != nil {
+			return nil, err
+		}
+		types = append(types, rt)
+	}
+	return types, rows.Err()
+}
+
+// AddRequestType registers name if it isn't already registered, returning
+// either the newly created or the pre-existing request type.
+func (d *Database) AddRequestType(ctx context.Context, name string) (model.RequestType, error) {
+	rt, err := d.GetRequestType(ctx, name)
+	if err == nil {
+		return rt, nil
+	}
+
+	_, err = d.ExecContext(ctx,
+		`INSERT INTO request_types (name, created_at) VALUES ($1, now()) ON CONFLICT (name) DO NOTHING`, name)
+	if err != nil {
+		return model.RequestType{}, err
+	}
+	return d.GetRequestType(ctx, name)
+}
+
+// GetRequestType looks up a single request type by name.
+func (d *Database) GetRequestType(ctx context.Context, name string) (model.RequestType, error) {
+	var rt model.RequestType
+	row := d.QueryRowContext(ctx, `SELECT name, created_at FROM request_types WHERE name = $1`, name)
+	if err := row.Scan(&rt.Name, &rt.CreatedAt); err != nil {
+		return model.RequestType{}, fmt.Errorf("request type %q not found: %w", name, err)
+	}
+	return rt, nil
+}
+
+// ListRequestStatusCodes returns every registered request status code.
+func (d *Database) ListRequestStatusCodes(ctx context.Context) ([]model.RequestStatusCode, error) {
+	rows, err := d.QueryContext(ctx, `SELECT code FROM request_status_codes ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []model.RequestStatusCode
+	for rows.Next() {
+		var code model.RequestStatusCode
+		if err := rows.Scan(&code.Code); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// InsertRequest records a newly submitted request. exec is either the
+// *Database itself or a *sql.Tx, so bulk submission can share one
+// transaction across several calls to InsertRequest.
+func InsertRequest(ctx context.Context, exec execer, requestType, requestingUser, details string) (model.RequestSummary, error) {
+	now := time.Now()
+	summary := model.RequestSummary{
+		RequestType:     requestType,
+		RequestingUser:  requestingUser,
+		Details:         details,
+		Status:          "submitted",
+		SubmittedDate:   now,
+		LastUpdatedDate: now,
+	}
+
+	row := exec.QueryRowContext(ctx,
+		`INSERT INTO requests (request_type, requesting_user, details, status, submitted_date, last_updated_date)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id`,
+		requestType, requestingUser, details, summary.Status, summary.SubmittedDate, summary.LastUpdatedDate)
+	if err := row.Scan(&summary.ID); err != nil {
+		return model.RequestSummary{}, err
+	}
+	return summary, nil
+}
+
+// WithSavepoint runs fn inside a named savepoint on tx. If fn returns an
+// error, the savepoint (and only the savepoint) is rolled back, leaving the
+// rest of tx intact; Postgres would otherwise mark the whole transaction
+// aborted the moment one statement in it fails. This is what lets bulk
+// submission share one transaction across items without one bad item
+// poisoning the others.
+func WithSavepoint(ctx context.Context, tx *sql.Tx, name string, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT `+name); err != nil {
+		return fmt.Errorf("creating savepoint %s: %w", name, err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT `+name); rbErr != nil {
+			return fmt.Errorf("rolling back savepoint %s after %w: %v", name, err, rbErr)
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT `+name); err != nil {
+		return fmt.Errorf("releasing savepoint %s: %w", name, err)
+	}
+	return nil
+}
+
+// ListRequests returns every submitted request.
+func (d *Database) ListRequests(ctx context.Context) ([]model.RequestSummary, error) {
+	rows, err := d.QueryContext(ctx,
+		`SELECT id, request_type, requesting_user, details, status, submitted_date, last_updated_date FROM requests`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []model.RequestSummary
+	for rows.Next() {
+		var s model.RequestSummary
+		if err := rows.Scan(&s.ID, &s.RequestType, &s.RequestingUser, &s.Details, &s.Status, &s.SubmittedDate, &s.LastUpdatedDate); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
+
+// GetRequest looks up a single request by id.
+func (d *Database) GetRequest(ctx context.Context, id string) (model.RequestSummary, error) {
+	var s model.RequestSummary
+	row := d.QueryRowContext(ctx,
+		`SELECT id, request_type, requesting_user, details, status, submitted_date, last_updated_date
+		 FROM requests WHERE id = $1`, id)
+	if err := row.Scan(&s.ID, &s.RequestType, &s.RequestingUser, &s.Details, &s.Status, &s.SubmittedDate, &s.LastUpdatedDate); err != nil {
+		return model.RequestSummary{}, fmt.Errorf("request %q not found: %w", id, err)
+	}
+	return s, nil
+}
+
+// UpdateRequestStatus transitions a request to newStatus, returning the
+// status it transitioned from along with the updated summary.
+func (d *Database) UpdateRequestStatus(ctx context.Context, id, newStatus string) (fromStatus string, summary model.RequestSummary, err error) {
+	existing, err := d.GetRequest(ctx, id)
+	if err != nil {
+		return "", model.RequestSummary{}, err
+	}
+
+	_, err = d.ExecContext(ctx,
+		`UPDATE requests SET status = $1, last_updated_date = now() WHERE id = $2`, newStatus, id)
+	if err != nil {
+		return "", model.RequestSummary{}, err
+	}
+
+	updated, err := d.GetRequest(ctx, id)
+	if err != nil {
+		return "", model.RequestSummary{}, err
+	}
+	return existing.Status, updated, nil
+}
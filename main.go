@@ -4,18 +4,20 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/cyverse-de/requests/clients/notificationagent"
+	"github.com/cyverse-de/requests/clients/webhooks"
+	"github.com/cyverse-de/requests/pkg/metrics"
+	"github.com/cyverse-de/requests/pkg/tracing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/jaeger"
-	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
-	tracesdk "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
 
 	"github.com/cyverse-de/requests/clients/iplantgroups"
 
@@ -63,58 +65,10 @@ func buildLoggerEntry() *logrus.Entry {
 		"group":   "org.cyverse",
 	})
 }
-func jaegerTracerProvider(url string) (*tracesdk.TracerProvider, error) {
-	// Create the Jaeger exporter
-	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(url)))
-	if err != nil {
-		return nil, err
-	}
-
-	tp := tracesdk.NewTracerProvider(
-		tracesdk.WithBatcher(exp),
-		tracesdk.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String("requests"),
-		)),
-	)
-
-	return tp, nil
-}
-
 func init() {
 	flag.Parse()
 
 	log = buildLoggerEntry()
-	var tracerProvider *tracesdk.TracerProvider
-
-	otelTracesExporter := os.Getenv("OTEL_TRACES_EXPORTER")
-	if otelTracesExporter == "jaeger" {
-		jaegerEndpoint := os.Getenv("OTEL_EXPORTER_JAEGER_ENDPOINT")
-		if jaegerEndpoint == "" {
-			log.Warn("Jaeger set as OpenTelemetry trace exporter, but no Jaeger endpoint configured.")
-		} else {
-			tp, err := jaegerTracerProvider(jaegerEndpoint)
-			if err != nil {
-				log.Fatal(err)
-			}
-			tracerProvider = tp
-			otel.SetTracerProvider(tp)
-			otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-		}
-	}
-
-	if tracerProvider != nil {
-		tracerCtx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		defer func(tracerContext context.Context) {
-			ctx, cancel := context.WithTimeout(tracerContext, time.Second*5)
-			defer cancel()
-			if err := tracerProvider.Shutdown(ctx); err != nil {
-				log.Fatal(err)
-			}
-		}(tracerCtx)
-	}
 }
 
 // CustomValidator represents a validator that Echo can use to check incoming requests.
@@ -128,6 +82,11 @@ func (cv CustomValidator) Validate(i interface{}) error {
 }
 
 func main() {
+	// Listen for the signals that tell us to shut down, so that the tracer
+	// provider gets a chance to flush its span batches before we exit.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	e := echo.New()
 
 	// Set a custom logger.
@@ -136,12 +95,54 @@ func main() {
 	// Register a custom validator.
 	e.Validator = &CustomValidator{validator: validator.New()}
 
-	// Add middleware.
+	// Load the configuration file.
+	e.Logger.Info("loading the configuration file")
+	cfg, err := configurate.Init(*cfgPath)
+	if err != nil {
+		e.Logger.Fatalf("unable to load the configuration file: %s", err.Error())
+	}
+
+	// Set up tracing before installing the Echo middleware so that the
+	// otelecho middleware has a tracer provider to record spans against.
+	tracerShutdown, err := tracing.Init(ctx, tracing.Config{
+		ServiceName:        "requests",
+		Exporter:           cfg.GetString("tracing.exporter"),
+		JaegerEndpoint:     cfg.GetString("tracing.jaeger_endpoint"),
+		OTLPProtocol:       cfg.GetString("tracing.otlp_protocol"),
+		OTLPEndpoint:       cfg.GetString("tracing.otlp_endpoint"),
+		OTLPInsecure:       cfg.GetBool("tracing.otlp_insecure"),
+		Sampler:            cfg.GetString("tracing.sampler"),
+		SamplerArg:         cfg.GetString("tracing.sampler_arg"),
+		ResourceAttributes: cfg.GetStringMapString("tracing.resource_attributes"),
+	})
+	if err != nil {
+		e.Logger.Fatalf("unable to initialize tracing: %s", err.Error())
+	}
+
+	// Add middleware. metrics.Middleware() is registered ahead of Recover so
+	// that a panicking handler is still counted and timed: Echo runs
+	// middleware as nested layers in registration order, so anything
+	// registered after Recover would have its post-handler code skipped when
+	// Recover catches a panic below it.
+	metricsEnabled := cfg.GetBool("metrics.enabled")
+	if metricsEnabled {
+		e.Use(metrics.Middleware())
+	}
 	e.Use(otelecho.Middleware("requests"))
+	e.Use(api.BaggageAttributesMiddleware(cfg.GetStringSlice("tracing.baggage_attributes")))
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 	e.Use(redoc.Serve(redoc.Opts{Title: "DE Administrative Requests API Documentation"}))
 
+	// Expose the metrics collected above.
+	if metricsEnabled {
+		metricsPath := cfg.GetString("metrics.path")
+		if metricsPath == "" {
+			metricsPath = "/metrics"
+		}
+		e.GET(metricsPath, echo.WrapHandler(promhttp.Handler()))
+	}
+
 	// Load the service information from the Swagger JSON.
 	e.Logger.Info("loading service information")
 	serviceInfo, err := getSwaggerServiceInfo()
@@ -149,13 +150,6 @@ func main() {
 		e.Logger.Fatal(err)
 	}
 
-	// Load the configuration file.
-	e.Logger.Info("loading the configuration file")
-	cfg, err := configurate.Init(*cfgPath)
-	if err != nil {
-		e.Logger.Fatalf("unable to load the configuration file: %s", err.Error())
-	}
-
 	// Initialize the database connection.
 	e.Logger.Info("establishing the database connection")
 	databaseURI := cfg.GetString("db.uri")
@@ -176,6 +170,10 @@ func main() {
 	// Create the notification-agent client.
 	notificationAgentClient := notificationagent.NewClient(cfg.GetString("notification_agent.base"))
 
+	// Set up webhook dispatch, persisting subscriptions and the
+	// delivery-attempt log in the same Postgres database as everything else.
+	webhookDispatcher := webhooks.NewDispatcher(webhooks.NewSQLStore(db.DB), log, 0)
+
 	// Define the API.
 	a := api.API{
 		Echo:                    e,
@@ -187,6 +185,7 @@ func main() {
 		IPlantEmailClient:       iplantEmailClient,
 		IPlantGroupsClient:      iplantGroupsClient,
 		NotificationAgentClient: notificationAgentClient,
+		Webhooks:                webhookDispatcher,
 	}
 
 	// Define the API endpoints.
@@ -198,10 +197,40 @@ func main() {
 	e.GET("/request-status-codes", a.GetRequestStatusCodesHandler)
 	e.GET("/requests", a.GetRequestsHandler)
 	e.POST("/requests", a.AddRequestHandler)
+	e.POST("/requests/bulk", a.BulkSubmitRequestHandler)
 	e.GET("/requests/:id", a.GetRequestDetailsHandler)
 	e.POST("/requests/:id/status", a.UpdateRequestHandler)
+	e.POST("/webhooks", a.RegisterWebhookHandler)
+	e.GET("/webhooks", a.ListWebhooksHandler)
+	e.DELETE("/webhooks/:id", a.DeleteWebhookHandler)
+	e.POST("/webhooks/:id/redeliver/:delivery_id", a.RedeliverWebhookHandler)
 
 	// Start the service.
-	e.Logger.Info("starting the service")
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%s", *port)))
+	go func() {
+		e.Logger.Info("starting the service")
+		if err := e.Start(fmt.Sprintf(":%s", *port)); err != nil && err != http.ErrServerClosed {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	// Block until a shutdown signal arrives, then give in-flight requests and
+	// the tracer provider a chance to wind down cleanly. Both run in this,
+	// the main goroutine, rather than one firing off detached in the
+	// background: main returning doesn't wait for other goroutines, so a
+	// detached tracer shutdown could get killed mid-flush by the process
+	// exiting right after e.Shutdown returns.
+	<-ctx.Done()
+	e.Logger.Info("shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Errorf("error shutting down the server: %s", err.Error())
+	}
+
+	tracerShutdownCtx, cancelTracer := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracer()
+	if err := tracerShutdown(tracerShutdownCtx); err != nil {
+		e.Logger.Errorf("error shutting down tracer provider: %s", err.Error())
+	}
 }
@@ -0,0 +1,57 @@
+// Package model holds the data types shared between the API layer and the
+// database layer.
+package model
+
+import "time"
+
+// RootResponse describes the service itself.
+type RootResponse struct {
+	Service string `json:"service"`
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// RequestType is a category of administrative request that can be submitted.
+type RequestType struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RequestTypeListing is the response body for GET /request-types.
+type RequestTypeListing struct {
+	RequestTypes []RequestType `json:"request_types"`
+}
+
+// RequestStatusCode is a status a request can be in.
+type RequestStatusCode struct {
+	Code string `json:"code"`
+}
+
+// RequestStatusCodeListing is the response body for GET /request-status-codes.
+type RequestStatusCodeListing struct {
+	RequestStatusCodes []RequestStatusCode `json:"request_status_codes"`
+}
+
+// RequestSubmission is the body accepted by POST /requests.
+type RequestSubmission struct {
+	RequestType string `json:"request_type" validate:"required"`
+	Details     string `json:"details"`
+}
+
+// RequestSummary is the response body for a single submitted request.
+type RequestSummary struct {
+	ID              string    `json:"id"`
+	RequestType     string    `json:"request_type"`
+	RequestingUser  string    `json:"requesting_user"`
+	Details         string    `json:"details"`
+	Status          string    `json:"status"`
+	SubmittedDate   time.Time `json:"submitted_date"`
+	LastUpdatedDate time.Time `json:"last_updated_date"`
+}
+
+// RequestStatusUpdate is the body accepted by POST /requests/{id}/status.
+type RequestStatusUpdate struct {
+	Status       string `json:"status" validate:"required"`
+	UpdatingUser string `json:"updating_user"`
+	Message      string `json:"message"`
+}
@@ -0,0 +1,96 @@
+// Package metrics exposes Prometheus counters and histograms for the
+// service: RED (rate, errors, duration) signals for every Echo route, plus a
+// handful of domain-specific metrics for request submissions and outbound
+// client calls. This gives operators the same signals the tracing setup in
+// pkg/tracing already targets, without requiring a trace backend.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// requestsTotal and requestDuration are the RED signals, labeled by the
+	// route's registered path (not the raw URL) so cardinality stays bounded
+	// regardless of path parameters like request IDs.
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "de_requests_http_requests_total",
+		Help: "Total HTTP requests handled, labeled by method, route, and status code.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "de_requests_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// RequestsSubmittedTotal counts administrative request submissions by
+	// type and the outcome of the submission itself, incremented by
+	// AddRequestHandler and the bulk submission endpoint.
+	RequestsSubmittedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "de_requests_submitted_total",
+		Help: "Total administrative requests submitted, labeled by request type and status.",
+	}, []string{"request_type", "status"})
+
+	// RequestsStatusTransitionsTotal counts status-code transitions applied
+	// to existing requests, incremented by UpdateRequestHandler once it
+	// applies a transition.
+	RequestsStatusTransitionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "de_requests_status_transitions_total",
+		Help: "Total request status transitions, labeled by the from and to status codes.",
+	}, []string{"from", "to"})
+
+	// OutboundClientDuration measures latency of calls made to the iplant-email,
+	// iplant-groups, and notification-agent clients.
+	OutboundClientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "de_requests_outbound_client_duration_seconds",
+		Help:    "Latency of outbound calls to dependent services, labeled by client and outcome.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"client", "outcome"})
+)
+
+// ObserveOutboundCall records the duration of a call to one of the outbound
+// clients (iplantemail, iplantgroups, notificationagent). outcome is
+// typically "ok" or "error".
+func ObserveOutboundCall(client, outcome string, duration time.Duration) {
+	OutboundClientDuration.WithLabelValues(client, outcome).Observe(duration.Seconds())
+}
+
+// Middleware instruments every request with the RED metrics above. It reads
+// the matched route's registered path via c.Path(), not c.Request().URL.Path,
+// so that path parameters (request IDs, webhook IDs, ...) don't blow up
+// label cardinality.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = "not_found"
+			}
+			method := c.Request().Method
+
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				} else if status < 400 {
+					status = 500
+				}
+			}
+
+			requestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+			requestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package tracing configures the OpenTelemetry SDK for the service based on
+// the standard OTEL_* environment variables plus a handful of values pulled
+// from the service configuration file. It replaces the old Jaeger-only setup
+// in main, which never actually flushed its span batches on shutdown.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// ShutdownFunc flushes any buffered spans and tears down the tracer provider.
+// It's safe to call even when tracing was never enabled.
+type ShutdownFunc func(ctx context.Context) error
+
+// Config describes how the tracer provider should be constructed. Exporter,
+// Sampler, and the various endpoints fall back to the equivalent OTEL_*
+// environment variables when left empty, so operators can keep using the
+// standard variables or set them in jobservices.yml under the `tracing` key.
+type Config struct {
+	ServiceName string
+
+	// Exporter selects the span exporter: "jaeger", "otlp", "stdout", or
+	// "none". Falls back to OTEL_TRACES_EXPORTER, defaulting to "none".
+	Exporter string
+
+	// JaegerEndpoint is the Jaeger collector HTTP endpoint, used when
+	// Exporter is "jaeger". Falls back to OTEL_EXPORTER_JAEGER_ENDPOINT.
+	JaegerEndpoint string
+
+	// OTLPEndpoint is the collector endpoint used when Exporter is "otlp".
+	// Falls back to OTEL_EXPORTER_OTLP_ENDPOINT. The protocol is chosen with
+	// OTLPProtocol ("grpc", the default, or "http/protobuf").
+	OTLPEndpoint string
+	OTLPProtocol string
+	OTLPInsecure bool
+
+	// Sampler and SamplerArg mirror OTEL_TRACES_SAMPLER and
+	// OTEL_TRACES_SAMPLER_ARG (e.g. "parentbased_traceidratio" + "0.25").
+	Sampler    string
+	SamplerArg string
+
+	// ResourceAttributes are additional resource attributes to attach to
+	// every span, typically sourced from the `tracing.resource_attributes`
+	// config section.
+	ResourceAttributes map[string]string
+}
+
+// getenvDefault returns fallback (the value from Config) when it's set, and
+// only consults the OTEL_* environment variable named by key when fallback
+// is empty, matching Config's documented precedence: jobservices.yml wins,
+// the standard OTEL_* variable is the true fallback.
+func getenvDefault(key, fallback string) string {
+	if fallback != "" {
+		return fallback
+	}
+	return os.Getenv(key)
+}
+
+// buildSampler translates the OTEL_TRACES_SAMPLER convention into a
+// tracesdk.Sampler, defaulting to always-on parent-based sampling.
+func buildSampler(cfg Config) (tracesdk.Sampler, error) {
+	name := getenvDefault("OTEL_TRACES_SAMPLER", cfg.Sampler)
+	arg := getenvDefault("OTEL_TRACES_SAMPLER_ARG", cfg.SamplerArg)
+
+	switch name {
+	case "", "parentbased_always_on":
+		return tracesdk.ParentBased(tracesdk.AlwaysSample()), nil
+	case "always_on":
+		return tracesdk.AlwaysSample(), nil
+	case "always_off":
+		return tracesdk.NeverSample(), nil
+	case "traceidratio", "parentbased_traceidratio":
+		ratio := 1.0
+		if arg != "" {
+			if _, err := fmt.Sscanf(arg, "%f", &ratio); err != nil {
+				return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q: %w", arg, err)
+			}
+		}
+		ratioSampler := tracesdk.TraceIDRatioBased(ratio)
+		if name == "parentbased_traceidratio" {
+			return tracesdk.ParentBased(ratioSampler), nil
+		}
+		return ratioSampler, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_SAMPLER %q", name)
+	}
+}
+
+// buildExporter constructs the span exporter named by cfg.Exporter (or
+// OTEL_TRACES_EXPORTER). A nil exporter with a nil error means tracing is
+// disabled.
+func buildExporter(ctx context.Context, cfg Config) (tracesdk.SpanExporter, error) {
+	name := getenvDefault("OTEL_TRACES_EXPORTER", cfg.Exporter)
+
+	switch name {
+	case "", "none":
+		return nil, nil
+
+	case "jaeger":
+		endpoint := getenvDefault("OTEL_EXPORTER_JAEGER_ENDPOINT", cfg.JaegerEndpoint)
+		if endpoint == "" {
+			return nil, fmt.Errorf("jaeger exporter requested but no collector endpoint configured")
+		}
+		return jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(endpoint)))
+
+	case "otlp":
+		endpoint := getenvDefault("OTEL_EXPORTER_OTLP_ENDPOINT", cfg.OTLPEndpoint)
+		protocol := getenvDefault("OTEL_EXPORTER_OTLP_PROTOCOL", cfg.OTLPProtocol)
+
+		if protocol == "http/protobuf" {
+			opts := []otlptracehttp.Option{}
+			if endpoint != "" {
+				opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+			}
+			if cfg.OTLPInsecure {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			return otlptracehttp.New(ctx, opts...)
+		}
+
+		opts := []otlptracegrpc.Option{}
+		if endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q", name)
+	}
+}
+
+// Init builds and installs a global tracer provider and propagator according
+// to cfg. It returns a ShutdownFunc that the caller must invoke (typically on
+// SIGTERM/SIGINT) so buffered spans are flushed before the process exits. If
+// no exporter is configured, Init still installs the propagator but returns
+// a no-op ShutdownFunc.
+func Init(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	noop := func(context.Context) error { return nil }
+
+	exp, err := buildExporter(ctx, cfg)
+	if err != nil {
+		return noop, err
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if exp == nil {
+		return noop, nil
+	}
+
+	sampler, err := buildSampler(cfg)
+	if err != nil {
+		return noop, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(cfg.ServiceName)}
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithSampler(sampler),
+		tracesdk.WithBatcher(exp),
+		tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}